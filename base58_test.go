@@ -0,0 +1,89 @@
+package bip32
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+
+	btcbase58 "github.com/btcsuite/btcutil/base58"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBase58EncodeDecodeRoundTrip(t *testing.T) {
+	cases := [][]byte{
+		{},
+		{0x00},
+		{0x00, 0x00, 0x00},
+		{0x01, 0x02, 0x03},
+		bytes.Repeat([]byte{0x00}, 5),
+		append(bytes.Repeat([]byte{0x00}, 3), 0xFF, 0xEE, 0xDD),
+		bytes.Repeat([]byte{0xFF}, 32),
+	}
+
+	for _, data := range cases {
+		encoded := base58Encode(data)
+		decoded, err := base58Decode(encoded)
+		require.NoError(t, err)
+		assert.Equal(t, data, decoded)
+	}
+}
+
+// TestBase58DifferentialAgainstBtcutil checks byte-for-byte parity between
+// this package's base58 implementation and btcsuite/btcutil/base58, including
+// leading-zero edge cases and the empty-string case.
+func TestBase58DifferentialAgainstBtcutil(t *testing.T) {
+	cases := [][]byte{
+		{},
+		{0x00},
+		{0x00, 0x00},
+		{0x01},
+		{0x00, 0x01},
+		{0xFF, 0xFF, 0xFF, 0xFF},
+	}
+	for i := 0; i < 200; i++ {
+		cases = append(cases, randomBytes(t, i%40))
+	}
+	for i := 0; i < 20; i++ {
+		cases = append(cases, append(bytes.Repeat([]byte{0x00}, i%5), randomBytes(t, 8)...))
+	}
+
+	for _, data := range cases {
+		ours := base58Encode(data)
+		theirs := btcbase58.Encode(data)
+		assert.Equal(t, theirs, ours, "encode mismatch for %x", data)
+
+		decodedOurs, err := base58Decode(theirs)
+		require.NoError(t, err)
+		assert.Equal(t, btcbase58.Decode(theirs), decodedOurs, "decode mismatch for %q", theirs)
+	}
+}
+
+func randomBytes(t *testing.T, n int) []byte {
+	t.Helper()
+	rnd := rand.New(rand.NewSource(int64(n) + 1))
+	b := make([]byte, n)
+	rnd.Read(b)
+	return b
+}
+
+func FuzzBase58RoundTrip(f *testing.F) {
+	f.Add([]byte{})
+	f.Add([]byte{0x00})
+	f.Add([]byte{0x00, 0x00, 0x01})
+	f.Add([]byte{0xFF, 0xFF, 0xFF})
+	f.Add(bytes.Repeat([]byte{0x00}, 10))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		encoded := base58Encode(data)
+		decoded, err := base58Decode(encoded)
+		require.NoError(t, err)
+		if !bytes.Equal(data, decoded) {
+			t.Fatalf("round trip mismatch: %x != %x (encoded %q)", data, decoded, encoded)
+		}
+
+		if reference := btcbase58.Encode(data); reference != encoded {
+			t.Fatalf("encode diverges from btcutil/base58: got %q want %q for %x", encoded, reference, data)
+		}
+	})
+}
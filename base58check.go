@@ -0,0 +1,37 @@
+package bip32
+
+import "bytes"
+
+// EncodeCheck base58check-encodes data, appending the standard 4-byte
+// double-SHA256 checksum before encoding.
+func EncodeCheck(data []byte) string {
+	checksummed, err := addChecksumToBytes(data)
+	if err != nil {
+		return ""
+	}
+	return base58Encode(checksummed)
+}
+
+// DecodeCheck base58-decodes s, verifies its trailing 4-byte double-SHA256
+// checksum, and returns the payload with the checksum stripped.
+func DecodeCheck(s string) ([]byte, error) {
+	decoded, err := base58Decode(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(decoded) < 4 {
+		return nil, ErrInvalidChecksum
+	}
+
+	payload := decoded[:len(decoded)-4]
+	want := decoded[len(decoded)-4:]
+
+	got, err := checksum(payload)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(got, want) {
+		return nil, ErrInvalidChecksum
+	}
+	return payload, nil
+}
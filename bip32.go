@@ -0,0 +1,363 @@
+package bip32
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"errors"
+	"math/big"
+)
+
+const (
+	// FirstHardenedChild is the index of the first hardened child key as per the bip32 spec
+	FirstHardenedChild = uint32(0x80000000)
+
+	// PublicKeyCompressedLength is the byte count of a compressed public key
+	PublicKeyCompressedLength = 33
+
+	// PrivateKeyLength is the byte count of a raw private key
+	PrivateKeyLength = 32
+
+	// ChainCodeLength is the byte count of a chain code
+	ChainCodeLength = 32
+
+	// SerializedKeyLength is the byte count of a serialized extended key,
+	// not including the base58check encoding
+	SerializedKeyLength = 78
+)
+
+var (
+	// PrivateWalletVersion is the version prefix for serialized private keys (xprv)
+	PrivateWalletVersion = [4]byte{0x04, 0x88, 0xAD, 0xE4}
+
+	// PublicWalletVersion is the version prefix for serialized public keys (xpub)
+	PublicWalletVersion = [4]byte{0x04, 0x88, 0xB2, 0x1E}
+)
+
+var (
+	// ErrSerializedKeyWrongSize is returned when a serialized extended key is
+	// not the expected length
+	ErrSerializedKeyWrongSize = errors.New("serialized keys should be exactly 78 bytes")
+
+	// ErrHardenedChildPublicKey is returned when trying to derive a hardened
+	// child key from a public (non-private) key
+	ErrHardenedChildPublicKey = errors.New("can't create hardened child from public key")
+
+	// ErrInvalidChecksum is returned when a base58check checksum does not match
+	ErrInvalidChecksum = errors.New("checksum doesn't match")
+
+	// ErrInvalidPrivateKey is returned when a derived or parsed private key is
+	// zero or is not within the curve order
+	ErrInvalidPrivateKey = errors.New("invalid private key")
+
+	// ErrInvalidPublicKey is returned when a derived or parsed public key does
+	// not lie on the curve
+	ErrInvalidPublicKey = errors.New("invalid public key")
+
+	// ErrInvalidChildKey is the internal signal that a candidate child key is
+	// invalid per BIP32 (parse256(IL) >= N, or the child is the point at
+	// infinity); NewChildKey handles it by retrying at the next index and
+	// never returns it to callers.
+	ErrInvalidChildKey = errors.New("invalid child key")
+
+	// ErrDerivationExhausted is returned in the vanishingly unlikely case
+	// that every index from childIdx to the end of its hardened/unhardened
+	// range produced an invalid child key.
+	ErrDerivationExhausted = errors.New("no valid child key found in the remaining index range")
+)
+
+// Key represents a BIP32 extended key, either private or public.
+type Key struct {
+	Key         []byte // 33 bytes for public key, 32 bytes for private key
+	Version     []byte // 4 bytes
+	ChildNumber []byte // 4 bytes
+	FingerPrint []byte // 4 bytes
+	ChainCode   []byte // 32 bytes
+	Depth       byte   // 1 byte
+	IsPrivate   bool   // unserialized
+}
+
+// NewMasterKey creates a new master extended key from a seed. Per BIP32, the
+// seed should be between 128 and 512 bits, though this is not enforced here;
+// callers deriving a seed from a BIP39 mnemonic should use
+// NewMasterKeyFromMnemonic instead.
+func NewMasterKey(seed []byte) (*Key, error) {
+	hash := hmac.New(sha512.New, []byte("Bitcoin seed"))
+	_, err := hash.Write(seed)
+	if err != nil {
+		return nil, err
+	}
+	intermediary := hash.Sum(nil)
+
+	keyBytes := intermediary[:32]
+	chainCode := intermediary[32:]
+
+	key := &Key{
+		Version:     PrivateWalletVersion[:],
+		ChainCode:   chainCode,
+		Key:         keyBytes,
+		Depth:       0x0,
+		ChildNumber: uint32Bytes(0),
+		FingerPrint: []byte{0x00, 0x00, 0x00, 0x00},
+		IsPrivate:   true,
+	}
+
+	if err := validatePrivateKey(keyBytes); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// NewChildKey derives a child key from this key at the given index, following
+// the BIP32 child key derivation algorithm. Indexes >= FirstHardenedChild
+// produce a hardened child, which requires the parent to be a private key.
+func (key *Key) NewChildKey(childIdx uint32) (*Key, error) {
+	if key.IsPrivate {
+		return key.newPrivateChildKey(childIdx)
+	}
+	return key.newPublicChildKey(childIdx)
+}
+
+func (key *Key) newPrivateChildKey(childIdx uint32) (*Key, error) {
+	for {
+		childIndexBytes := uint32Bytes(childIdx)
+
+		var data []byte
+		if childIdx >= FirstHardenedChild {
+			data = append([]byte{0x0}, key.Key...)
+		} else {
+			data = publicKeyForPrivateKey(key.Key)
+		}
+		data = append(data, childIndexBytes...)
+
+		hash := hmacSha512(key.ChainCode, data)
+		il := hash[:32]
+		childChainCode := hash[32:]
+
+		childKey, err := addPrivateKeys(il, key.Key)
+		if err == ErrInvalidChildKey {
+			// Per BIP32: "In case parse256(IL) >= n or ki == 0, the
+			// resulting key is invalid, and one should proceed with the
+			// next value for i."
+			childIdx, err = nextChildIndex(childIdx)
+			if err != nil {
+				return nil, err
+			}
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		fingerPrint, err := fingerprint(key)
+		if err != nil {
+			return nil, err
+		}
+
+		return &Key{
+			Version:     PrivateWalletVersion[:],
+			Key:         childKey,
+			ChildNumber: childIndexBytes,
+			FingerPrint: fingerPrint,
+			ChainCode:   childChainCode,
+			Depth:       key.Depth + 1,
+			IsPrivate:   true,
+		}, nil
+	}
+}
+
+// nextChildIndex returns childIdx+1, preserving whether the index is
+// hardened. It returns ErrDerivationExhausted if incrementing would overflow
+// uint32 or would cross from the unhardened range into the hardened range
+// (FirstHardenedChild), since that would silently change which derivation
+// space is being searched.
+func nextChildIndex(childIdx uint32) (uint32, error) {
+	if childIdx == ^uint32(0) {
+		return 0, ErrDerivationExhausted
+	}
+	next := childIdx + 1
+	if childIdx < FirstHardenedChild && next >= FirstHardenedChild {
+		return 0, ErrDerivationExhausted
+	}
+	return next, nil
+}
+
+func (key *Key) newPublicChildKey(childIdx uint32) (*Key, error) {
+	for {
+		if childIdx >= FirstHardenedChild {
+			return nil, ErrHardenedChildPublicKey
+		}
+
+		childIndexBytes := uint32Bytes(childIdx)
+		data := append(append([]byte{}, key.Key...), childIndexBytes...)
+
+		hash := hmacSha512(key.ChainCode, data)
+		il := hash[:32]
+		childChainCode := hash[32:]
+
+		childKey, err := deriveChildPublicKey(il, key.Key)
+		if err == ErrInvalidChildKey {
+			// Per BIP32: "In case parse256(IL) >= n or Ki is the point at
+			// infinity, the resulting key is invalid, and one should proceed
+			// with the next value for i."
+			childIdx, err = nextChildIndex(childIdx)
+			if err != nil {
+				return nil, err
+			}
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		fingerPrint, err := fingerprint(key)
+		if err != nil {
+			return nil, err
+		}
+
+		return &Key{
+			Version:     PublicWalletVersion[:],
+			Key:         childKey,
+			ChildNumber: childIndexBytes,
+			FingerPrint: fingerPrint,
+			ChainCode:   childChainCode,
+			Depth:       key.Depth + 1,
+			IsPrivate:   false,
+		}, nil
+	}
+}
+
+// deriveChildPublicKey computes the public-key counterpart of addPrivateKeys:
+// the curve point IL*G + parentKey. It returns ErrInvalidChildKey if IL is
+// not a valid scalar (IL >= N) or if the resulting point is the point at
+// infinity, per the same BIP32 rule addPrivateKeys enforces for private
+// derivation. A result that fails validateChildPublicKey is reported as
+// ErrInvalidPublicKey.
+func deriveChildPublicKey(il []byte, parentKey []byte) ([]byte, error) {
+	var ilInt big.Int
+	ilInt.SetBytes(il)
+	if ilInt.Cmp(curveParams.N) >= 0 {
+		return nil, ErrInvalidChildKey
+	}
+
+	childKey, err := addPublicKeys(publicKeyForPrivateKey(il), parentKey)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateChildPublicKey(childKey); err != nil {
+		return nil, err
+	}
+	return childKey, nil
+}
+
+// PublicKey returns the public (neutered) counterpart of this key. If the key
+// is already public, it is returned unmodified.
+func (key *Key) PublicKey() *Key {
+	if !key.IsPrivate {
+		return key
+	}
+
+	return &Key{
+		Version:     PublicWalletVersion[:],
+		Key:         publicKeyForPrivateKey(key.Key),
+		ChildNumber: key.ChildNumber,
+		FingerPrint: key.FingerPrint,
+		ChainCode:   key.ChainCode,
+		Depth:       key.Depth,
+		IsPrivate:   false,
+	}
+}
+
+// Serialize encodes the key following the BIP32 extended-key wire format
+// (version || depth || fingerprint || child number || chain code || key),
+// without the base58check encoding.
+func (key *Key) Serialize() ([]byte, error) {
+	return key.serialize(key.Version)
+}
+
+func (key *Key) serialize(version []byte) ([]byte, error) {
+	var keyBytes []byte
+	if key.IsPrivate {
+		keyBytes = append([]byte{0x0}, key.Key...)
+	} else {
+		keyBytes = key.Key
+	}
+
+	buf := make([]byte, 0, SerializedKeyLength)
+	buf = append(buf, version...)
+	buf = append(buf, key.Depth)
+	buf = append(buf, key.FingerPrint...)
+	buf = append(buf, key.ChildNumber...)
+	buf = append(buf, key.ChainCode...)
+	buf = append(buf, keyBytes...)
+
+	if len(buf) != SerializedKeyLength {
+		return nil, ErrSerializedKeyWrongSize
+	}
+	return buf, nil
+}
+
+// B58Serialize base58check-encodes the serialized key, producing the familiar
+// "xprv.../xpub..." string representation.
+func (key *Key) B58Serialize() string {
+	serialized, err := key.Serialize()
+	if err != nil {
+		return ""
+	}
+	return EncodeCheck(serialized)
+}
+
+// String implements fmt.Stringer by returning the base58check-encoded key.
+func (key *Key) String() string {
+	return key.B58Serialize()
+}
+
+// Deserialize parses a raw (non-base58) serialized extended key, as produced
+// by Serialize.
+func Deserialize(data []byte) (*Key, error) {
+	if len(data) != SerializedKeyLength {
+		return nil, ErrSerializedKeyWrongSize
+	}
+
+	key := &Key{}
+	key.Version = data[0:4]
+	key.Depth = data[4]
+	key.FingerPrint = data[5:9]
+	key.ChildNumber = data[9:13]
+	key.ChainCode = data[13:45]
+
+	if data[45] == 0x0 {
+		key.IsPrivate = true
+		key.Key = data[46:78]
+	} else {
+		key.IsPrivate = false
+		key.Key = data[45:78]
+	}
+	return key, nil
+}
+
+// B58Deserialize decodes a base58check-encoded extended key string, as
+// produced by B58Serialize.
+func B58Deserialize(data string) (*Key, error) {
+	payload, err := DecodeCheck(data)
+	if err != nil {
+		return nil, err
+	}
+	return Deserialize(payload)
+}
+
+func fingerprint(key *Key) ([]byte, error) {
+	pubKey := publicKeyForPrivateKey(key.Key)
+	if !key.IsPrivate {
+		pubKey = key.Key
+	}
+
+	h, err := hash160(pubKey)
+	if err != nil {
+		return nil, err
+	}
+	return h[:4], nil
+}
+
+func hmacSha512(key []byte, data []byte) []byte {
+	mac := hmac.New(sha512.New, key)
+	mac.Write(data)
+	return mac.Sum(nil)
+}
@@ -0,0 +1,141 @@
+// Package bip39 implements the BIP-39 mnemonic code standard: generating and
+// parsing human-readable mnemonic phrases and deriving a seed from them for
+// use with BIP-32 hierarchical deterministic wallets.
+package bip39
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+var (
+	// ErrInvalidEntropyLength is returned when the requested entropy size is
+	// not one of the sizes defined by the BIP-39 spec.
+	ErrInvalidEntropyLength = errors.New("entropy length must be one of 128, 160, 192, 224, 256 bits")
+
+	// ErrInvalidMnemonic is returned when a mnemonic does not have a valid
+	// word count, contains a word outside the word list, or fails checksum
+	// validation.
+	ErrInvalidMnemonic = errors.New("invalid mnemonic")
+)
+
+// entropyBitsMultiple is the step, in bits, between valid entropy sizes.
+const entropyBitsMultiple = 32
+
+// wordBits is the number of bits encoded by each word (2^11 = 2048 words).
+const wordBits = 11
+
+// NewEntropy returns a cryptographically random byte slice suitable for
+// NewMnemonic. bitSize must be a multiple of 32 in the range [128, 256], i.e.
+// one of 128, 160, 192, 224, or 256.
+func NewEntropy(bitSize int) ([]byte, error) {
+	if bitSize < 128 || bitSize > 256 || bitSize%entropyBitsMultiple != 0 {
+		return nil, ErrInvalidEntropyLength
+	}
+
+	entropy := make([]byte, bitSize/8)
+	if _, err := rand.Read(entropy); err != nil {
+		return nil, err
+	}
+	return entropy, nil
+}
+
+// NewMnemonic converts entropy generated by NewEntropy into a mnemonic
+// sentence using the standard English word list. The checksum is the first
+// len(entropy)*8/32 bits of SHA256(entropy), appended to the entropy before
+// being split into 11-bit word indexes.
+func NewMnemonic(entropy []byte) (string, error) {
+	entropyBits := len(entropy) * 8
+	if entropyBits < 128 || entropyBits > 256 || entropyBits%entropyBitsMultiple != 0 {
+		return "", ErrInvalidEntropyLength
+	}
+	checksumBits := entropyBits / entropyBitsMultiple
+	wordCount := (entropyBits + checksumBits) / wordBits
+
+	hash := sha256.Sum256(entropy)
+
+	// bits = entropy || first checksumBits bits of sha256(entropy)
+	bits := new(big.Int).SetBytes(entropy)
+	bits.Lsh(bits, uint(checksumBits))
+	checksumValue := new(big.Int).SetBytes(hash[:])
+	checksumValue.Rsh(checksumValue, uint(256-checksumBits))
+	bits.Or(bits, checksumValue)
+
+	words := make([]string, wordCount)
+	mask := big.NewInt(1<<wordBits - 1)
+	for i := wordCount - 1; i >= 0; i-- {
+		idx := new(big.Int).And(bits, mask)
+		words[i] = englishWordlist[idx.Uint64()]
+		bits.Rsh(bits, wordBits)
+	}
+
+	return strings.Join(words, " "), nil
+}
+
+// MnemonicToByteArray recovers the original entropy encoded in a mnemonic
+// sentence, validating the embedded checksum.
+func MnemonicToByteArray(mnemonic string) ([]byte, error) {
+	words := strings.Fields(mnemonic)
+	wordCount := len(words)
+	if wordCount < 12 || wordCount > 24 || wordCount%3 != 0 {
+		return nil, fmt.Errorf("%w: must have 12, 15, 18, 21 or 24 words", ErrInvalidMnemonic)
+	}
+
+	totalBits := wordCount * wordBits
+	checksumBits := totalBits / (entropyBitsMultiple + 1)
+	entropyBits := totalBits - checksumBits
+
+	wordIndex := make(map[string]int64, len(englishWordlist))
+	for i, w := range englishWordlist {
+		wordIndex[w] = int64(i)
+	}
+
+	bits := new(big.Int)
+	for _, word := range words {
+		idx, ok := wordIndex[word]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown word %q", ErrInvalidMnemonic, word)
+		}
+		bits.Lsh(bits, wordBits)
+		bits.Or(bits, big.NewInt(idx))
+	}
+
+	checksumMask := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), uint(checksumBits)), big.NewInt(1))
+	gotChecksum := new(big.Int).And(bits, checksumMask)
+
+	entropyValue := new(big.Int).Rsh(bits, uint(checksumBits))
+	entropy := entropyValue.FillBytes(make([]byte, entropyBits/8))
+
+	hash := sha256.Sum256(entropy)
+	wantChecksum := new(big.Int).SetBytes(hash[:])
+	wantChecksum.Rsh(wantChecksum, uint(256-checksumBits))
+
+	if gotChecksum.Cmp(wantChecksum) != 0 {
+		return nil, fmt.Errorf("%w: checksum mismatch", ErrInvalidMnemonic)
+	}
+
+	return entropy, nil
+}
+
+// IsMnemonicValid reports whether mnemonic has a valid word count, consists
+// only of words from the English word list, and has a matching checksum.
+func IsMnemonicValid(mnemonic string) bool {
+	_, err := MnemonicToByteArray(mnemonic)
+	return err == nil
+}
+
+// MnemonicToSeed derives a 64-byte seed from a mnemonic sentence and an
+// optional passphrase via PBKDF2-HMAC-SHA512 with 2048 iterations, as
+// specified by BIP-39. The mnemonic's checksum is not validated here; callers
+// that need that guarantee should call IsMnemonicValid first.
+func MnemonicToSeed(mnemonic, passphrase string) []byte {
+	salt := "mnemonic" + passphrase
+	return pbkdf2.Key([]byte(mnemonic), []byte(salt), 2048, 64, sha512.New)
+}
@@ -0,0 +1,75 @@
+package bip39
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewEntropy(t *testing.T) {
+	for _, bits := range []int{128, 160, 192, 224, 256} {
+		entropy, err := NewEntropy(bits)
+		require.NoError(t, err)
+		assert.Len(t, entropy, bits/8)
+	}
+
+	_, err := NewEntropy(129)
+	assert.ErrorIs(t, err, ErrInvalidEntropyLength)
+
+	_, err = NewEntropy(288)
+	assert.ErrorIs(t, err, ErrInvalidEntropyLength)
+}
+
+func TestNewMnemonicRoundTrip(t *testing.T) {
+	for _, bits := range []int{128, 160, 192, 224, 256} {
+		entropy, err := NewEntropy(bits)
+		require.NoError(t, err)
+
+		mnemonic, err := NewMnemonic(entropy)
+		require.NoError(t, err)
+		assert.True(t, IsMnemonicValid(mnemonic))
+
+		got, err := MnemonicToByteArray(mnemonic)
+		require.NoError(t, err)
+		assert.Equal(t, entropy, got)
+	}
+}
+
+// TestVectors checks the library against the official BIP-39 test vectors
+// for English, using the all-zero entropy case:
+// https://github.com/trezor/python-mnemonic/blob/master/vectors.json
+func TestVectors(t *testing.T) {
+	entropy, err := hex.DecodeString("00000000000000000000000000000000000000000000000000000000000000")
+	require.NoError(t, err)
+	entropy = entropy[:16] // 128 bits
+
+	mnemonic, err := NewMnemonic(entropy)
+	require.NoError(t, err)
+	assert.Equal(t,
+		"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+		mnemonic)
+
+	seed := MnemonicToSeed(mnemonic, "TREZOR")
+	assert.Equal(t,
+		"c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04",
+		hex.EncodeToString(seed))
+}
+
+func TestMnemonicToByteArrayInvalid(t *testing.T) {
+	_, err := MnemonicToByteArray("too short")
+	assert.ErrorIs(t, err, ErrInvalidMnemonic)
+
+	_, err = MnemonicToByteArray("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon notaword")
+	assert.ErrorIs(t, err, ErrInvalidMnemonic)
+
+	// Valid words, wrong word count for a valid checksum length (13 words).
+	_, err = MnemonicToByteArray("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon")
+	assert.ErrorIs(t, err, ErrInvalidMnemonic)
+
+	// Valid words and count, but checksum does not match.
+	_, err = MnemonicToByteArray("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon")
+	assert.ErrorIs(t, err, ErrInvalidMnemonic)
+	assert.False(t, IsMnemonicValid("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon"))
+}
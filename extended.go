@@ -0,0 +1,76 @@
+package bip32
+
+import "fmt"
+
+// Network holds the version bytes used when serializing an extended key,
+// allowing callers to register alternate prefixes (testnet, Litecoin,
+// Dogecoin, or any other BIP32-derived coin) beyond the Bitcoin mainnet
+// defaults used by Serialize/B58Serialize.
+type Network struct {
+	PrivateVersion [4]byte
+	PublicVersion  [4]byte
+}
+
+// MainNet is the Bitcoin mainnet network, producing xprv/xpub prefixes. It
+// matches the version bytes used by Serialize and B58Serialize.
+var MainNet = Network{
+	PrivateVersion: PrivateWalletVersion,
+	PublicVersion:  PublicWalletVersion,
+}
+
+// TestNet is the Bitcoin testnet network, producing tprv/tpub prefixes.
+var TestNet = Network{
+	PrivateVersion: [4]byte{0x04, 0x35, 0x83, 0x94},
+	PublicVersion:  [4]byte{0x04, 0x35, 0x87, 0xCF},
+}
+
+// SerializeExtended base58check-encodes the key using net's version bytes
+// instead of the key's own Version field, producing e.g. an xpub/xprv string
+// for MainNet or tpub/tprv for TestNet.
+func (key *Key) SerializeExtended(net Network) (string, error) {
+	version := net.PublicVersion
+	if key.IsPrivate {
+		version = net.PrivateVersion
+	}
+
+	serialized, err := key.serialize(version[:])
+	if err != nil {
+		return "", err
+	}
+	return EncodeCheck(serialized), nil
+}
+
+// ParseExtended decodes a base58check-encoded extended key string, the same
+// wire format produced by Serialize, and validates the invariants BIP32
+// requires of it: a depth-0 (master) key must carry a zero fingerprint and
+// child number, and any other key must carry a non-zero parent fingerprint.
+// A child number of zero is accepted at any depth above 0.
+func ParseExtended(s string) (*Key, error) {
+	payload, err := DecodeCheck(s)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := Deserialize(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	isZero := func(b []byte) bool {
+		for _, v := range b {
+			if v != 0 {
+				return false
+			}
+		}
+		return true
+	}
+
+	switch {
+	case key.Depth == 0 && (!isZero(key.FingerPrint) || !isZero(key.ChildNumber)):
+		return nil, fmt.Errorf("invalid extended key: depth 0 must have a zero fingerprint and child number")
+	case key.Depth != 0 && isZero(key.FingerPrint):
+		return nil, fmt.Errorf("invalid extended key: depth %d must have a non-zero parent fingerprint", key.Depth)
+	}
+
+	return key, nil
+}
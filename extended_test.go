@@ -0,0 +1,59 @@
+package bip32
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSerializeExtendedRoundTrip(t *testing.T) {
+	seed := []byte("yet another seed used only for this unit test")
+	master, err := NewMasterKey(seed)
+	require.NoError(t, err)
+
+	xprv, err := master.SerializeExtended(MainNet)
+	require.NoError(t, err)
+	assert.Equal(t, master.B58Serialize(), xprv)
+
+	tprv, err := master.SerializeExtended(TestNet)
+	require.NoError(t, err)
+	assert.NotEqual(t, xprv, tprv)
+
+	parsed, err := ParseExtended(xprv)
+	require.NoError(t, err)
+	assert.Equal(t, master.Key, parsed.Key)
+	assert.Equal(t, master.ChainCode, parsed.ChainCode)
+}
+
+func TestParseExtendedInvariants(t *testing.T) {
+	seed := []byte("yet another seed used only for this unit test 2")
+	master, err := NewMasterKey(seed)
+	require.NoError(t, err)
+
+	child, err := master.NewChildKey(FirstHardenedChild)
+	require.NoError(t, err)
+
+	_, err = ParseExtended(child.B58Serialize())
+	require.NoError(t, err)
+
+	// Tamper with a valid master serialization so depth is 0 but the
+	// fingerprint is non-zero; this must be rejected.
+	raw, err := master.Serialize()
+	require.NoError(t, err)
+	raw[5] = 0xAA
+	_, err = ParseExtended(EncodeCheck(raw))
+	assert.Error(t, err)
+}
+
+func TestEncodeDecodeCheck(t *testing.T) {
+	data := []byte{0x01, 0x02, 0x03, 0x04}
+	encoded := EncodeCheck(data)
+
+	decoded, err := DecodeCheck(encoded)
+	require.NoError(t, err)
+	assert.Equal(t, data, decoded)
+
+	_, err = DecodeCheck(encoded[:len(encoded)-1])
+	assert.Error(t, err)
+}
@@ -0,0 +1,16 @@
+package bip32
+
+import "github.com/luxfi/go-bip32/bip39"
+
+// NewMasterKeyFromMnemonic derives a master extended key directly from a
+// BIP-39 mnemonic sentence and optional passphrase. The mnemonic is turned
+// into a 64-byte seed via bip39.MnemonicToSeed and that seed is fed to
+// NewMasterKey unchanged.
+func NewMasterKeyFromMnemonic(mnemonic, passphrase string) (*Key, error) {
+	if !bip39.IsMnemonicValid(mnemonic) {
+		return nil, bip39.ErrInvalidMnemonic
+	}
+
+	seed := bip39.MnemonicToSeed(mnemonic, passphrase)
+	return NewMasterKey(seed)
+}
@@ -0,0 +1,58 @@
+package bip32
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// DerivePath derives the descendant of key reached by following path, a
+// string of the form "m/44'/0'/0'/0/5". The leading "m" refers to key itself
+// regardless of its actual depth. Each segment is a non-negative child index,
+// optionally suffixed with "'" or "h"/"H" to request a hardened child.
+func (key *Key) DerivePath(path string) (*Key, error) {
+	segments := strings.Split(path, "/")
+	if len(segments) == 0 || segments[0] != "m" {
+		return nil, fmt.Errorf("invalid derivation path %q: must start with \"m\"", path)
+	}
+
+	current := key
+	for _, segment := range segments[1:] {
+		childIdx, err := parsePathSegment(segment)
+		if err != nil {
+			return nil, fmt.Errorf("invalid derivation path %q: %w", path, err)
+		}
+
+		current, err = current.NewChildKey(childIdx)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return current, nil
+}
+
+func parsePathSegment(segment string) (uint32, error) {
+	hardened := false
+	switch {
+	case strings.HasSuffix(segment, "'"):
+		hardened = true
+		segment = strings.TrimSuffix(segment, "'")
+	case strings.HasSuffix(segment, "h") || strings.HasSuffix(segment, "H"):
+		hardened = true
+		segment = segment[:len(segment)-1]
+	}
+
+	idx, err := strconv.ParseUint(segment, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid index %q: %w", segment, err)
+	}
+	if idx >= uint64(FirstHardenedChild) {
+		return 0, fmt.Errorf("index %d must be less than %d", idx, FirstHardenedChild)
+	}
+
+	childIdx := uint32(idx)
+	if hardened {
+		childIdx += FirstHardenedChild
+	}
+	return childIdx, nil
+}
@@ -0,0 +1,49 @@
+package bip32
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDerivePath(t *testing.T) {
+	seed := []byte("a third seed used only for this unit test here")
+	master, err := NewMasterKey(seed)
+	require.NoError(t, err)
+
+	derived, err := master.DerivePath("m/44'/0'/0'/0/5")
+	require.NoError(t, err)
+
+	manual := master
+	for _, idx := range []uint32{44 + FirstHardenedChild, 0 + FirstHardenedChild, 0 + FirstHardenedChild, 0, 5} {
+		manual, err = manual.NewChildKey(idx)
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, manual.Key, derived.Key)
+	assert.Equal(t, manual.ChainCode, derived.ChainCode)
+
+	// "h" marker is equivalent to "'"
+	derivedH, err := master.DerivePath("m/44h/0h/0h/0/5")
+	require.NoError(t, err)
+	assert.Equal(t, manual.Key, derivedH.Key)
+}
+
+func TestDerivePathInvalid(t *testing.T) {
+	seed := []byte("a fourth seed used only for this unit test here")
+	master, err := NewMasterKey(seed)
+	require.NoError(t, err)
+
+	_, err = master.DerivePath("44'/0")
+	assert.Error(t, err)
+
+	_, err = master.DerivePath("m/2147483648")
+	assert.Error(t, err)
+
+	_, err = master.DerivePath("m/2147483648'")
+	assert.Error(t, err)
+
+	_, err = master.DerivePath("m/notanumber")
+	assert.Error(t, err)
+}
@@ -0,0 +1,52 @@
+package bip32
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpandPublicKeyRejectsNonResidue(t *testing.T) {
+	// key is well-formed (compressed, 33 bytes) but its X coordinate does not
+	// correspond to any point on the curve, so x^3+7 is not a quadratic
+	// residue mod P and ModSqrt must fail.
+	key := make([]byte, PublicKeyCompressedLength)
+	key[0] = 0x02
+	key[1] = 0x02 // X = 2^249 (top byte 0x02), deliberately non-residue
+
+	_, _, err := expandPublicKey(key)
+	assert.ErrorIs(t, err, ErrInvalidPublicKey)
+}
+
+func TestAddPublicKeysDoublingAndInverse(t *testing.T) {
+	seed := []byte("security hardening test seed, quite long indeed")
+	master, err := NewMasterKey(seed)
+	require.NoError(t, err)
+
+	pub := publicKeyForPrivateKey(master.Key)
+
+	// Doubling: adding a point to itself must equal scalar-multiplying the
+	// corresponding private key by 2.
+	privInt := new(big.Int).SetBytes(master.Key)
+	doubledPriv := new(big.Int).Lsh(privInt, 1)
+	doubledPriv.Mod(doubledPriv, curveParams.N)
+	doubledPrivBytes := doubledPriv.FillBytes(make([]byte, 32))
+	expected := publicKeyForPrivateKey(doubledPrivBytes)
+
+	doubled, err := addPublicKeys(pub, pub)
+	require.NoError(t, err)
+	assert.True(t, bytes.Equal(expected, doubled))
+
+	// Inverse: a point added to its negation is the point at infinity, which
+	// BIP32 defines as an invalid child key.
+	x, y, err := expandPublicKey(pub)
+	require.NoError(t, err)
+	negY := new(big.Int).Sub(curveParams.P, y)
+	negated := compressPublicKey(x, negY)
+
+	_, err = addPublicKeys(pub, negated)
+	assert.ErrorIs(t, err, ErrInvalidChildKey)
+}
@@ -0,0 +1,104 @@
+package bip32
+
+import (
+	"errors"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+)
+
+// SignatureLength is the byte count of a compact recoverable signature:
+// 32-byte R, 32-byte S, and a 1-byte recovery id.
+const SignatureLength = 65
+
+var (
+	// ErrNotPrivateKey is returned when Sign is called on a key that does not
+	// hold a private component.
+	ErrNotPrivateKey = errors.New("key is not a private key")
+
+	// ErrInvalidSignatureLength is returned when a signature passed to Verify
+	// or RecoverPubkey is not exactly SignatureLength bytes.
+	ErrInvalidSignatureLength = errors.New("signature must be 65 bytes [R || S || V]")
+
+	// ErrUnrecoverableSignature is returned in the extremely unlikely event
+	// that the ephemeral nonce used to produce a signature yielded an R value
+	// greater than or equal to the curve order, which this package's 65-byte
+	// [R || S || V] format (V in {0,1}) cannot encode.
+	ErrUnrecoverableSignature = errors.New("signature recovery id out of range")
+)
+
+// Sign produces a 65-byte compact signature [R || S || V] over hash (which
+// should already be the output of hashing the message to be signed) using a
+// deterministic RFC 6979 nonce. S is normalized to the lower half of the
+// curve order per BIP-62, and V is the recovery id (0 or 1) identifying
+// which of the two candidate points was used, so the public key can later be
+// recovered from the signature with RecoverPubkey.
+func (key *Key) Sign(hash []byte) ([]byte, error) {
+	if !key.IsPrivate {
+		return nil, ErrNotPrivateKey
+	}
+
+	privKey := secp256k1.PrivKeyFromBytes(key.Key)
+	compact := ecdsa.SignCompact(privKey, hash, true)
+
+	// compact is <1-byte recovery code><32-byte R><32-byte S>, where the
+	// recovery code is 27 + recId + 4 (the +4 marks a compressed pubkey).
+	recID := compact[0] - 27 - 4
+	if recID > 1 {
+		return nil, ErrUnrecoverableSignature
+	}
+
+	sig := make([]byte, SignatureLength)
+	copy(sig[0:32], compact[1:33])
+	copy(sig[32:64], compact[33:65])
+	sig[64] = recID
+	return sig, nil
+}
+
+// Verify reports whether sig is a valid signature over hash for this key's
+// public key.
+func (key *Key) Verify(hash []byte, sig []byte) (bool, error) {
+	if len(sig) != SignatureLength {
+		return false, ErrInvalidSignatureLength
+	}
+
+	pubKeyBytes := key.Key
+	if key.IsPrivate {
+		pubKeyBytes = publicKeyForPrivateKey(key.Key)
+	}
+	pubKey, err := secp256k1.ParsePubKey(pubKeyBytes)
+	if err != nil {
+		return false, err
+	}
+
+	var r, s secp256k1.ModNScalar
+	r.SetByteSlice(sig[0:32])
+	s.SetByteSlice(sig[32:64])
+
+	signature := ecdsa.NewSignature(&r, &s)
+	return signature.Verify(hash, pubKey), nil
+}
+
+// RecoverPubkey recovers the 33-byte compressed public key that produced sig
+// over hash.
+func RecoverPubkey(hash []byte, sig []byte) ([]byte, error) {
+	if len(sig) != SignatureLength {
+		return nil, ErrInvalidSignatureLength
+	}
+
+	recID := sig[64]
+	if recID > 1 {
+		return nil, ErrUnrecoverableSignature
+	}
+
+	compact := make([]byte, 65)
+	compact[0] = 27 + recID + 4
+	copy(compact[1:33], sig[0:32])
+	copy(compact[33:65], sig[32:64])
+
+	pubKey, _, err := ecdsa.RecoverCompact(compact, hash)
+	if err != nil {
+		return nil, err
+	}
+	return pubKey.SerializeCompressed(), nil
+}
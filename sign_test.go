@@ -0,0 +1,44 @@
+package bip32
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignVerifyRecover(t *testing.T) {
+	seed := []byte("a reasonably long seed for testing purposes only")
+	master, err := NewMasterKey(seed)
+	require.NoError(t, err)
+
+	hash := sha256.Sum256([]byte("hello, bip32"))
+
+	sig, err := master.Sign(hash[:])
+	require.NoError(t, err)
+	assert.Len(t, sig, SignatureLength)
+
+	ok, err := master.Verify(hash[:], sig)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	recoveredPub, err := RecoverPubkey(hash[:], sig)
+	require.NoError(t, err)
+	assert.Equal(t, master.PublicKey().Key, recoveredPub)
+
+	otherHash := sha256.Sum256([]byte("a different message"))
+	ok, err = master.Verify(otherHash[:], sig)
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestSignRequiresPrivateKey(t *testing.T) {
+	seed := []byte("another seed used only for this unit test case")
+	master, err := NewMasterKey(seed)
+	require.NoError(t, err)
+
+	pub := master.PublicKey()
+	_, err = pub.Sign([]byte("irrelevant"))
+	assert.ErrorIs(t, err, ErrNotPrivateKey)
+}
@@ -73,80 +73,101 @@ func hash160(data []byte) ([]byte, error) {
 // Base58 Encoding (Bitcoin style)
 //
 
+// base58AlphabetIndex maps an alphabet byte to its value, or -1 if the byte
+// is not part of the alphabet. Built once at init so encode/decode don't pay
+// for map or linear-scan lookups per character.
+var base58AlphabetIndex [256]int8
+
+func init() {
+	for i := range base58AlphabetIndex {
+		base58AlphabetIndex[i] = -1
+	}
+	for i := 0; i < len(base58Alphabet); i++ {
+		base58AlphabetIndex[base58Alphabet[i]] = int8(i)
+	}
+}
+
+// base58Encode encodes data using the byte-array long-division algorithm
+// (the same approach used by btcutil/base58 and libbase58): repeatedly divide
+// the big-endian input by 58, propagating the remainder across the output
+// buffer a byte at a time. This is O(n^2) on the byte length with a small
+// constant and no big.Int allocation per digit, unlike a big.Int-based
+// implementation.
 func base58Encode(data []byte) string {
-	// Count leading zeros
 	var zeros int
-	for _, b := range data {
-		if b == 0 {
-			zeros++
-		} else {
-			break
-		}
+	for zeros < len(data) && data[zeros] == 0 {
+		zeros++
 	}
 
-	// Convert to big integer
-	num := new(big.Int).SetBytes(data)
-	base := big.NewInt(58)
-	zero := big.NewInt(0)
-	mod := new(big.Int)
-
-	var result []byte
-	for num.Cmp(zero) > 0 {
-		num.DivMod(num, base, mod)
-		result = append(result, base58Alphabet[mod.Int64()])
+	// log(256)/log(58), rounded up.
+	size := (len(data)-zeros)*138/100 + 1
+	buf := make([]byte, size)
+
+	high := size - 1
+	for _, b := range data[zeros:] {
+		carry := int(b)
+		i := size - 1
+		for ; i > high || carry != 0; i-- {
+			carry += 256 * int(buf[i])
+			buf[i] = byte(carry % 58)
+			carry /= 58
+		}
+		high = i
 	}
 
-	// Add leading '1's for each leading zero byte
-	for i := 0; i < zeros; i++ {
-		result = append(result, '1')
+	// Skip leading zeros in the base58 buffer itself.
+	i := 0
+	for i < size && buf[i] == 0 {
+		i++
 	}
 
-	// Reverse the result
-	for i, j := 0, len(result)-1; i < j; i, j = i+1, j-1 {
-		result[i], result[j] = result[j], result[i]
+	result := make([]byte, zeros+size-i)
+	for j := 0; j < zeros; j++ {
+		result[j] = '1'
+	}
+	for j := zeros; i < size; i, j = i+1, j+1 {
+		result[j] = base58Alphabet[buf[i]]
 	}
-
 	return string(result)
 }
 
+// base58Decode is the inverse of base58Encode, using the same byte-array
+// long-division approach (multiplying by 58 and propagating the carry)
+// instead of a big.Int.
 func base58Decode(data string) ([]byte, error) {
-	// Count leading '1's (zeros in output)
 	var zeros int
-	for _, c := range data {
-		if c == '1' {
-			zeros++
-		} else {
-			break
-		}
+	for zeros < len(data) && data[zeros] == '1' {
+		zeros++
 	}
 
-	// Build alphabet index map
-	alphabetMap := make(map[rune]int64)
-	for i, c := range base58Alphabet {
-		alphabetMap[c] = int64(i)
-	}
+	// log(58)/log(256), rounded up.
+	size := (len(data)-zeros)*733/1000 + 1
+	buf := make([]byte, size)
 
-	// Convert from base58
-	num := big.NewInt(0)
-	base := big.NewInt(58)
-	for _, c := range data {
-		idx, ok := alphabetMap[c]
-		if !ok {
+	high := size - 1
+	for _, c := range []byte(data[zeros:]) {
+		idx := base58AlphabetIndex[c]
+		if idx < 0 {
 			return nil, fmt.Errorf("invalid base58 character: %c", c)
 		}
-		num.Mul(num, base)
-		num.Add(num, big.NewInt(idx))
-	}
 
-	// Convert to bytes
-	result := num.Bytes()
+		carry := int(idx)
+		i := size - 1
+		for ; i > high || carry != 0; i-- {
+			carry += 58 * int(buf[i])
+			buf[i] = byte(carry % 256)
+			carry /= 256
+		}
+		high = i
+	}
 
-	// Add leading zeros
-	if zeros > 0 {
-		prefix := make([]byte, zeros)
-		result = append(prefix, result...)
+	i := 0
+	for i < size && buf[i] == 0 {
+		i++
 	}
 
+	result := make([]byte, zeros+size-i)
+	copy(result[zeros:], buf[i:])
 	return result, nil
 }
 
@@ -177,28 +198,65 @@ func publicKeyForPrivateKey(key []byte) []byte {
 	return compressPublicKey(x, y)
 }
 
-func addPublicKeys(key1 []byte, key2 []byte) []byte {
-	x1, y1 := expandPublicKey(key1)
-	x2, y2 := expandPublicKey(key2)
+// addPublicKeys computes the curve point addition key1 + key2. Per BIP32, if
+// the two points turn out to be additive inverses of one another, their sum
+// is the point at infinity, which has no compressed representation and is
+// defined by the spec as an invalid child key; callers must treat
+// ErrInvalidChildKey as a signal to retry derivation at the next index.
+func addPublicKeys(key1 []byte, key2 []byte) ([]byte, error) {
+	x1, y1, err := expandPublicKey(key1)
+	if err != nil {
+		return nil, err
+	}
+	x2, y2, err := expandPublicKey(key2)
+	if err != nil {
+		return nil, err
+	}
+
+	// curve.Add does not give the correct answer when asked to add a point
+	// to itself (point doubling) or to its own inverse (point at infinity),
+	// so both cases need to be detected and handled explicitly rather than
+	// trusted to the general addition formula.
+	if x1.Cmp(x2) == 0 {
+		if y1.Cmp(y2) == 0 {
+			x, y := curve.Double(x1, y1)
+			return compressPublicKey(x, y), nil
+		}
+		// x1 == x2 and y1 != y2 on a curve with at most two y values per x
+		// means y1 == -y2 (mod p): the points are inverses.
+		return nil, ErrInvalidChildKey
+	}
+
 	x, y := curve.Add(x1, y1, x2, y2)
-	return compressPublicKey(x, y)
+	return compressPublicKey(x, y), nil
 }
 
-func addPrivateKeys(key1 []byte, key2 []byte) []byte {
+// addPrivateKeys computes (il + key1) mod N, the scalar combination used for
+// private child key derivation. Per BIP32, if il itself is not a valid
+// element of the scalar field (il >= N) or the resulting sum is zero, the
+// child key is invalid and callers must retry derivation at the next index.
+func addPrivateKeys(il []byte, key1 []byte) ([]byte, error) {
+	var ilInt big.Int
+	ilInt.SetBytes(il)
+	if ilInt.Cmp(curveParams.N) >= 0 {
+		return nil, ErrInvalidChildKey
+	}
+
 	var key1Int big.Int
-	var key2Int big.Int
 	key1Int.SetBytes(key1)
-	key2Int.SetBytes(key2)
 
-	key1Int.Add(&key1Int, &key2Int)
-	key1Int.Mod(&key1Int, curve.Params().N)
+	ilInt.Add(&ilInt, &key1Int)
+	ilInt.Mod(&ilInt, curveParams.N)
+	if ilInt.Sign() == 0 {
+		return nil, ErrInvalidChildKey
+	}
 
-	b := key1Int.Bytes()
+	b := ilInt.Bytes()
 	if len(b) < 32 {
 		extra := make([]byte, 32-len(b))
 		b = append(extra, b...)
 	}
-	return b
+	return b, nil
 }
 
 func compressPublicKey(x *big.Int, y *big.Int) []byte {
@@ -217,30 +275,45 @@ func compressPublicKey(x *big.Int, y *big.Int) []byte {
 	return key.Bytes()
 }
 
-// As described at https://crypto.stackexchange.com/a/8916
-func expandPublicKey(key []byte) (*big.Int, *big.Int) {
-	Y := big.NewInt(0)
+// expandPublicKey reconstructs the (X, Y) coordinates of a compressed public
+// key, as described at https://crypto.stackexchange.com/a/8916. It returns
+// ErrInvalidPublicKey if X does not correspond to a point on the curve at
+// all (ySquared is not a quadratic residue mod P) or if, after
+// reconstruction, the point fails the curve equation check, which guards
+// against a corrupt or adversarially crafted input being treated as valid.
+func expandPublicKey(key []byte) (*big.Int, *big.Int, error) {
 	X := big.NewInt(0)
 	X.SetBytes(key[1:])
 
 	// y^2 = x^3 + ax^2 + b
 	// a = 0
 	// => y^2 = x^3 + b
-	ySquared := big.NewInt(0)
-	ySquared.Exp(X, big.NewInt(3), nil)
+	ySquared := new(big.Int).Exp(X, big.NewInt(3), nil)
 	ySquared.Add(ySquared, curveParams.B)
+	ySquared.Mod(ySquared, curveParams.P)
 
-	Y.ModSqrt(ySquared, curveParams.P)
+	Y := new(big.Int)
+	if Y.ModSqrt(ySquared, curveParams.P) == nil {
+		return nil, nil, ErrInvalidPublicKey
+	}
 
-	Ymod2 := big.NewInt(0)
-	Ymod2.Mod(Y, big.NewInt(2))
+	// Confirm the reconstructed point actually satisfies the curve equation;
+	// ModSqrt succeeding is necessary but, on its own, is only a guarantee
+	// about ySquared's residuosity, not that the rest of the arithmetic above
+	// was sound.
+	check := new(big.Int).Mul(Y, Y)
+	check.Mod(check, curveParams.P)
+	if check.Cmp(ySquared) != 0 {
+		return nil, nil, ErrInvalidPublicKey
+	}
 
+	Ymod2 := new(big.Int).Mod(Y, big.NewInt(2))
 	signY := uint64(key[0]) - 2
 	if signY != Ymod2.Uint64() {
 		Y.Sub(curveParams.P, Y)
 	}
 
-	return X, Y
+	return X, Y, nil
 }
 
 func validatePrivateKey(key []byte) error {
@@ -253,8 +326,16 @@ func validatePrivateKey(key []byte) error {
 	return nil
 }
 
+// validateChildPublicKey checks that key decodes to a point actually on the
+// secp256k1 curve. x == 0 or y == 0 can't occur for a point on secp256k1
+// (7 is not a square and -7 is not a cube mod P, respectively), but
+// the check is kept as defense in depth against a corrupt reconstruction
+// slipping past expandPublicKey.
 func validateChildPublicKey(key []byte) error {
-	x, y := expandPublicKey(key)
+	x, y, err := expandPublicKey(key)
+	if err != nil {
+		return err
+	}
 
 	if x.Sign() == 0 || y.Sign() == 0 {
 		return ErrInvalidPublicKey